@@ -2,22 +2,83 @@ package eksconfig
 
 import (
 	"errors"
+	"fmt"
 	"path"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 
+	k8sobject "github.com/aws/aws-k8s-tester/pkg/k8s-object"
 	"github.com/aws/aws-k8s-tester/pkg/metrics"
 	"github.com/aws/aws-k8s-tester/pkg/randutil"
 	"github.com/aws/aws-k8s-tester/pkg/timeutil"
 )
 
+// KMSProviderAESCBC is the etcd envelope encryption provider backed by a
+// local AES-CBC key, the default Kubernetes encryption-at-rest provider.
+const KMSProviderAESCBC = "aescbc"
+
+// KMSProviderAESGCM is the etcd envelope encryption provider backed by a
+// local AES-GCM key.
+const KMSProviderAESGCM = "aesgcm"
+
+// KMSProviderKMSV1 is the etcd envelope encryption provider backed by the
+// KMS v1 plugin, which round-trips every write/read through the KMS plugin
+// over gRPC.
+const KMSProviderKMSV1 = "kms-v1"
+
+// KMSProviderKMSV2 is the etcd envelope encryption provider backed by the
+// KMS v2 plugin, which caches decrypted DEKs in the API server to avoid a
+// KMS call on every read.
+const KMSProviderKMSV2 = "kms-v2"
+
+// kmsProviders is the set of "KMSProviders" entries the secrets-remote tester
+// knows how to configure encryption-at-rest for.
+var kmsProviders = map[string]struct{}{
+	KMSProviderAESCBC: {},
+	KMSProviderAESGCM: {},
+	KMSProviderKMSV1:  {},
+	KMSProviderKMSV2:  {},
+}
+
+// LoadProfileConstant issues requests at a steady "TargetQPS" for the
+// duration of the run.
+const LoadProfileConstant = "constant"
+
+// LoadProfileRamp linearly increases the request rate from 0 to "TargetQPS"
+// over the duration of the run.
+const LoadProfileRamp = "ramp"
+
+// LoadProfileStep increases the request rate in discrete steps up to
+// "TargetQPS" over the duration of the run.
+const LoadProfileStep = "step"
+
+// LoadProfilePoisson issues requests at "TargetQPS" on average, with
+// inter-arrival times drawn from a Poisson process, to approximate bursty
+// real-world traffic.
+const LoadProfilePoisson = "poisson"
+
+// loadProfiles is the set of "LoadProfile" values "AddOnSecretsRemote" knows
+// how to generate.
+var loadProfiles = map[string]struct{}{
+	LoadProfileConstant: {},
+	LoadProfileRamp:     {},
+	LoadProfileStep:     {},
+	LoadProfilePoisson:  {},
+}
+
 // AddOnSecretsRemote defines parameters for EKS cluster
 // add-on "Secrets" remote.
 // It generates loads from the remote workers (Pod) in the cluster.
-// Each worker writes serially with no concurrency.
-// Configure "DeploymentReplicas" accordingly to increase the concurrency.
+// Each worker can write/read with "WritesPerWorker"/"ReadsPerWorker"
+// concurrent goroutines, shaped by "LoadProfile" and rate-limited to
+// "TargetQPS" across all replicas. Configure "DeploymentReplicas" and
+// these concurrency knobs together to reach the desired aggregate QPS.
 // The main use case is to write a large number of objects to fill up etcd database.
-// And measure latencies for secret encryption.
+// And measure latencies for secret encryption, comparing the etcd KMS providers
+// listed in "KMSProviders" against each other on the same cluster.
 type AddOnSecretsRemote struct {
 	// Enable is 'true' to create this add-on.
 	Enable bool `json:"enable"`
@@ -48,64 +109,158 @@ type AddOnSecretsRemote struct {
 	// ObjectSize is the "Secret" value size in bytes.
 	ObjectSize int `json:"object-size"`
 
+	// WritesPerWorker is the number of concurrent write goroutines per worker Pod.
+	WritesPerWorker int `json:"writes-per-worker,omitempty"`
+	// ReadsPerWorker is the number of concurrent read goroutines per worker Pod.
+	ReadsPerWorker int `json:"reads-per-worker,omitempty"`
+	// TargetQPS is the aggregate requests/second for the secrets-remote
+	// tester to rate-limit writes and reads to, combined, across all
+	// "DeploymentReplicas". 0 disables the limit. This is the requested
+	// rate; compare it against
+	// "RequestsWritesAchievedQPS"/"RequestsReadsAchievedQPS" once the run
+	// completes.
+	TargetQPS float64 `json:"target-qps,omitempty"`
+	// LoadProfile is the request-rate shape used to reach "TargetQPS"
+	// (one of "constant", "ramp", "step", "poisson").
+	LoadProfile string `json:"load-profile,omitempty"`
+
+	// RequestsWritesEffectiveQPS is the enforced writes/second ceiling,
+	// computed as "DeploymentReplicas" * "WritesPerWorker" and, when
+	// "TargetQPS" is set, scaled down proportionally with
+	// "RequestsReadsEffectiveQPS" so the two never exceed it combined.
+	RequestsWritesEffectiveQPS float64 `json:"requests-writes-effective-qps,omitempty" read-only:"true"`
+	// RequestsReadsEffectiveQPS is the read equivalent of
+	// "RequestsWritesEffectiveQPS".
+	RequestsReadsEffectiveQPS float64 `json:"requests-reads-effective-qps,omitempty" read-only:"true"`
+
+	// RequestsWritesAchievedQPS is the writes/second actually achieved, as
+	// reported by the secrets-remote tester, to compare against
+	// "RequestsWritesEffectiveQPS".
+	RequestsWritesAchievedQPS float64 `json:"requests-writes-achieved-qps,omitempty" read-only:"true"`
+	// RequestsReadsAchievedQPS is the read equivalent of
+	// "RequestsWritesAchievedQPS".
+	RequestsReadsAchievedQPS float64 `json:"requests-reads-achieved-qps,omitempty" read-only:"true"`
+
 	// NamePrefix is the prefix of Secret name.
 	// If multiple Secret loader is running,
 	// this must be unique per worker to avoid name conflicts.
 	NamePrefix string `json:"name-prefix"`
 
-	// S3Dir is the S3 directory to store all test results.
-	// It is under the bucket "eksconfig.Config.S3BucketName".
-	S3Dir string `json:"s3-dir"`
+	// KMSProviders is the matrix of etcd envelope encryption providers for
+	// the secrets-remote tester to benchmark in this run (e.g. "aescbc",
+	// "aesgcm", "kms-v1", "kms-v2"). For each provider, the tester is
+	// expected to configure the cluster's encryption-at-rest accordingly,
+	// run the full write/read load, and record the results keyed by
+	// provider in
+	// "RequestsWritesSummaryByProvider"/"RequestsReadsSummaryByProvider".
+	KMSProviders []string `json:"kms-providers"`
+	// KMSKeyARN is the KMS key ARN used by the "kms-v1"/"kms-v2" providers.
+	// Required when "KMSProviders" contains "kms-v1" or "kms-v2".
+	KMSKeyARN string `json:"kms-key-arn,omitempty"`
+	// KMSPluginImage is the container image for the KMS plugin consumed by
+	// the "kms-v1"/"kms-v2" providers.
+	KMSPluginImage string `json:"kms-plugin-image,omitempty"`
+	// RotationInterval is the DEK rotation interval the KMS plugin is
+	// configured with. 0 disables rotation.
+	RotationInterval time.Duration `json:"rotation-interval,omitempty"`
+
+	// RequestsWritesSummaryByProvider is "RequestsWritesSummary" segmented by
+	// "KMSProviders" entry, so AES-CBC vs AES-GCM vs KMS v1 vs KMS v2 latency
+	// and throughput can be compared on the same cluster.
+	RequestsWritesSummaryByProvider map[string]metrics.RequestsSummary `json:"requests-writes-summary-by-provider,omitempty" read-only:"true"`
+	// RequestsReadsSummaryByProvider is the read equivalent of
+	// "RequestsWritesSummaryByProvider".
+	RequestsReadsSummaryByProvider map[string]metrics.RequestsSummary `json:"requests-reads-summary-by-provider,omitempty" read-only:"true"`
+
+	// NodeSelectorMinKubeletVersion is the minimum kubelet minor version,
+	// parsed from "NodeSystemInfo.KubeletVersion" by "kubeletMinorVersion",
+	// a node must have for the secrets-remote tester to schedule worker
+	// replicas onto it. Assumes all nodes share the same major version
+	// (true for an EKS cluster's node groups) and compares on the integer
+	// minor version alone, e.g. 24 for "v1.24.7-eks-...", so it orders
+	// correctly across the x.10 boundary (unlike
+	// "NodeInfo.KubeletMinorVersionValue", which encodes major.minor as a
+	// single float and would rank 1.9 above 1.24). 0 disables the filter.
+	NodeSelectorMinKubeletVersion int `json:"node-selector-min-kubelet-version,omitempty"`
+	// NodeSelectorKernelRegex restricts worker scheduling to nodes whose
+	// "NodeSystemInfo.KernelVersion" matches this regular expression.
+	// Empty disables the filter.
+	NodeSelectorKernelRegex string `json:"node-selector-kernel-regex,omitempty"`
+
+	// RequestsWritesSummaryByNodeVersion is "RequestsWritesSummary"
+	// segmented by the kubelet minor-version bucket (e.g. "v1.24", as
+	// returned by "NodeVersionBucket") of the nodes that produced the
+	// requests, so a mixed-version node group upgrade can be compared in a
+	// single run. Each bucket's results are also written under
+	// "writes-summary/v1.24/..." relative to "ResultsSinkDir".
+	RequestsWritesSummaryByNodeVersion map[string]metrics.RequestsSummary `json:"requests-writes-summary-by-node-version,omitempty" read-only:"true"`
+	// RequestsReadsSummaryByNodeVersion is the read equivalent of
+	// "RequestsWritesSummaryByNodeVersion".
+	RequestsReadsSummaryByNodeVersion map[string]metrics.RequestsSummary `json:"requests-reads-summary-by-node-version,omitempty" read-only:"true"`
+
+	// ResultsSinkDir is the base "ResultsSink" all other result sinks are
+	// joined onto, e.g. "s3://bucket/prefix", "gs://bucket/prefix", or
+	// "file:///var/log/...". Supports S3, GCS, and MinIO/S3-compatible
+	// backends via a custom "Endpoint" and "CredentialsSecretName".
+	ResultsSinkDir ResultsSink `json:"results-sink-dir"`
 
 	// RequestsWritesRawJSONPath is the file path to store writes requests in JSON format.
-	RequestsWritesRawJSONPath  string `json:"requests-writes-json-path" read-only:"true"`
-	RequestsWritesRawJSONS3Key string `json:"requests-writes-json-s3-key" read-only:"true"`
+	RequestsWritesRawJSONPath string `json:"requests-writes-json-path" read-only:"true"`
+	RequestsWritesRawJSONSink ResultsSink `json:"requests-writes-json-sink" read-only:"true"`
 	// RequestsWritesSummary is the writes results.
 	RequestsWritesSummary metrics.RequestsSummary `json:"requests-writes-summary,omitempty" read-only:"true"`
 	// RequestsWritesSummaryJSONPath is the file path to store writes requests summary in JSON format.
-	RequestsWritesSummaryJSONPath  string `json:"requests-writes-summary-json-path" read-only:"true"`
-	RequestsWritesSummaryJSONS3Key string `json:"requests-writes-summary-json-s3-key" read-only:"true"`
+	RequestsWritesSummaryJSONPath string `json:"requests-writes-summary-json-path" read-only:"true"`
+	RequestsWritesSummaryJSONSink ResultsSink `json:"requests-writes-summary-json-sink" read-only:"true"`
 	// RequestsWritesSummaryTablePath is the file path to store writes requests summary in table format.
-	RequestsWritesSummaryTablePath  string `json:"requests-writes-summary-table-path" read-only:"true"`
-	RequestsWritesSummaryTableS3Key string `json:"requests-writes-summary-table-s3-path" read-only:"true"`
-	// RequestsWritesSummaryS3Dir is the S3 directory of previous/latest "RequestsWritesSummary".
-	// Specify the S3 key in the same bucket of "eksconfig.Config.S3BucketName".
+	RequestsWritesSummaryTablePath string `json:"requests-writes-summary-table-path" read-only:"true"`
+	RequestsWritesSummaryTableSink ResultsSink `json:"requests-writes-summary-table-sink" read-only:"true"`
+	// RequestsWritesSummarySinkDir is the sink directory of previous/latest "RequestsWritesSummary".
 	// Use for regression tests. Specify the value not bound to the cluster directory.
-	// Different runs from different clusters reads and writes in this directory.
-	RequestsWritesSummaryS3Dir string `json:"requests-writes-summary-s3-dir"`
+	// Different runs from different clusters read and write in this directory.
+	RequestsWritesSummarySinkDir ResultsSink `json:"requests-writes-summary-sink-dir"`
 	// RequestsWritesSummaryCompare is the comparision results.
 	RequestsWritesSummaryCompare metrics.RequestsSummaryCompare `json:"requests-writes-summary-compare" read-only:"true"`
 	// RequestsWritesSummaryCompareJSONPath is the file path to store writes requests compare summary in JSON format.
-	RequestsWritesSummaryCompareJSONPath  string `json:"requests-writes-summary-compare-json-path" read-only:"true"`
-	RequestsWritesSummaryCompareJSONS3Key string `json:"requests-writes-summary-compare-json-s3-key" read-only:"true"`
+	RequestsWritesSummaryCompareJSONPath string `json:"requests-writes-summary-compare-json-path" read-only:"true"`
+	RequestsWritesSummaryCompareJSONSink ResultsSink `json:"requests-writes-summary-compare-json-sink" read-only:"true"`
 	// RequestsWritesSummaryCompareTablePath is the file path to store writes requests compare summary in table format.
-	RequestsWritesSummaryCompareTablePath  string `json:"requests-writes-summary-compare-table-path" read-only:"true"`
-	RequestsWritesSummaryCompareTableS3Key string `json:"requests-writes-summary-compare-table-s3-path" read-only:"true"`
+	RequestsWritesSummaryCompareTablePath string `json:"requests-writes-summary-compare-table-path" read-only:"true"`
+	RequestsWritesSummaryCompareTableSink ResultsSink `json:"requests-writes-summary-compare-table-sink" read-only:"true"`
 
 	// RequestsReadsRawJSONPath is the file path to store reads requests in JSON format.
-	RequestsReadsRawJSONPath  string `json:"requests-reads-raw-json-path" read-only:"true"`
-	RequestsReadsRawJSONS3Key string `json:"requests-reads-raw-json-s3-key" read-only:"true"`
+	RequestsReadsRawJSONPath string `json:"requests-reads-raw-json-path" read-only:"true"`
+	RequestsReadsRawJSONSink ResultsSink `json:"requests-reads-raw-json-sink" read-only:"true"`
 	// RequestsReadsSummary is the reads results.
 	RequestsReadsSummary metrics.RequestsSummary `json:"requests-reads-summary,omitempty" read-only:"true"`
 	// RequestsReadsSummaryJSONPath is the file path to store reads requests summary in JSON format.
-	RequestsReadsSummaryJSONPath  string `json:"requests-reads-summary-json-path" read-only:"true"`
-	RequestsReadsSummaryJSONS3Key string `json:"requests-reads-summary-json-s3-key" read-only:"true"`
+	RequestsReadsSummaryJSONPath string `json:"requests-reads-summary-json-path" read-only:"true"`
+	RequestsReadsSummaryJSONSink ResultsSink `json:"requests-reads-summary-json-sink" read-only:"true"`
 	// RequestsReadsSummaryTablePath is the file path to store reads requests summary in table format.
-	RequestsReadsSummaryTablePath  string `json:"requests-reads-summary-table-path" read-only:"true"`
-	RequestsReadsSummaryTableS3Key string `json:"requests-reads-summary-table-s3-path" read-only:"true"`
-	// RequestsReadsSummaryS3Dir is the S3 directory of previous/latest "RequestsReadsSummary".
-	// Specify the S3 key in the same bucket of "eksconfig.Config.S3BucketName".
+	RequestsReadsSummaryTablePath string `json:"requests-reads-summary-table-path" read-only:"true"`
+	RequestsReadsSummaryTableSink ResultsSink `json:"requests-reads-summary-table-sink" read-only:"true"`
+	// RequestsReadsSummarySinkDir is the sink directory of previous/latest "RequestsReadsSummary".
 	// Use for regression tests. Specify the value not bound to the cluster directory.
-	// Different runs from different clusters reads and writes in this directory.
-	RequestsReadsSummaryS3Dir string `json:"requests-reads-summary-s3-dir"`
+	// Different runs from different clusters read and write in this directory.
+	RequestsReadsSummarySinkDir ResultsSink `json:"requests-reads-summary-sink-dir"`
 	// RequestsReadsSummaryCompare is the comparision results.
 	RequestsReadsSummaryCompare metrics.RequestsSummaryCompare `json:"requests-reads-summary-compare" read-only:"true"`
 	// RequestsReadsSummaryCompareJSONPath is the file path to store reads requests compare summary in JSON format.
-	RequestsReadsSummaryCompareJSONPath  string `json:"requests-reads-summary-compare-json-path" read-only:"true"`
-	RequestsReadsSummaryCompareJSONS3Key string `json:"requests-reads-summary-compare-json-s3-key" read-only:"true"`
+	RequestsReadsSummaryCompareJSONPath string `json:"requests-reads-summary-compare-json-path" read-only:"true"`
+	RequestsReadsSummaryCompareJSONSink ResultsSink `json:"requests-reads-summary-compare-json-sink" read-only:"true"`
 	// RequestsReadsSummaryCompareTablePath is the file path to store reads requests compare summary in table format.
-	RequestsReadsSummaryCompareTablePath  string `json:"requests-reads-summary-compare-table-path" read-only:"true"`
-	RequestsReadsSummaryCompareTableS3Key string `json:"requests-reads-summary-compare-table-s3-path" read-only:"true"`
+	RequestsReadsSummaryCompareTablePath string `json:"requests-reads-summary-compare-table-path" read-only:"true"`
+	RequestsReadsSummaryCompareTableSink ResultsSink `json:"requests-reads-summary-compare-table-sink" read-only:"true"`
+
+	// RegressionThresholds defines the maximum allowed regression between
+	// "RequestsWritesSummaryCompare"/"RequestsReadsSummaryCompare" before and
+	// after results, keyed by "cfg.Parameters.Version".
+	RegressionThresholds RegressionThresholds `json:"regression-thresholds"`
+	// FailOnRegression is true to have the runner exit non-zero when the
+	// comparison breaches any "RegressionThresholds", turning this add-on
+	// into a CI gate for etcd/KMS performance regressions across cluster
+	// versions.
+	FailOnRegression bool `json:"fail-on-regression"`
 
 	// RequestsWritesSummaryOutputNamePrefix is the output path name in "/var/log" directory, used in remote worker.
 	RequestsWritesSummaryOutputNamePrefix string `json:"requests-writes-summary-output-name-prefix"`
@@ -113,6 +268,71 @@ type AddOnSecretsRemote struct {
 	RequestsReadsSummaryOutputNamePrefix string `json:"requests-reads-summary-output-name-prefix"`
 }
 
+// RegressionThresholds defines the maximum allowed delta between the
+// "before" and "after" sides of a "metrics.RequestsSummaryCompare", beyond
+// which the run is considered a regression.
+type RegressionThresholds struct {
+	// MaxP50DeltaMilliseconds is the max allowed increase in p50 latency.
+	MaxP50DeltaMilliseconds float64 `json:"max-p50-delta-milliseconds"`
+	// MaxP90DeltaMilliseconds is the max allowed increase in p90 latency.
+	MaxP90DeltaMilliseconds float64 `json:"max-p90-delta-milliseconds"`
+	// MaxP99DeltaMilliseconds is the max allowed increase in p99 latency.
+	MaxP99DeltaMilliseconds float64 `json:"max-p99-delta-milliseconds"`
+	// MaxErrorRateDelta is the max allowed increase in error rate (0.0 to 1.0).
+	MaxErrorRateDelta float64 `json:"max-error-rate-delta"`
+	// MinThroughputDelta is the max allowed fractional decrease in
+	// throughput, e.g. "-0.1" allows up to a 10% drop relative to the
+	// "before" side's requests/second; a negative-or-zero delta.
+	MinThroughputDelta float64 `json:"min-throughput-delta"`
+}
+
+// defaultRegressionThresholds are applied when "RegressionThresholds" is
+// left at its zero value, so enabling "FailOnRegression" works out of the
+// box with sane defaults.
+var defaultRegressionThresholds = RegressionThresholds{
+	MaxP50DeltaMilliseconds: 20,
+	MaxP90DeltaMilliseconds: 50,
+	MaxP99DeltaMilliseconds: 100,
+	MaxErrorRateDelta:       0.01,
+	MinThroughputDelta:      -0.1, // allow up to a 10% throughput drop
+}
+
+// kubeletMinorVersionRegex extracts the minor version number from a kubelet
+// version string, e.g. "v1.24.7-eks-6d3986b" -> "24".
+var kubeletMinorVersionRegex = regexp.MustCompile(`^v?[0-9]+\.([0-9]+)`)
+
+// kubeletMinorVersion parses the integer minor version out of
+// "kubeletVersion", returning "ok" false if it cannot be parsed. Unlike
+// "k8sobject.NodeInfo.KubeletMinorVersionValue", which packs major.minor
+// into a single float and therefore mis-orders across the x.10 boundary
+// (1.9 > 1.24 as floats), this keeps the minor version as a plain int so
+// "NodeSelectorMinKubeletVersion" and "NodeVersionBucket" compare correctly.
+func kubeletMinorVersion(kubeletVersion string) (minor int, ok bool) {
+	m := kubeletMinorVersionRegex.FindStringSubmatch(kubeletVersion)
+	if len(m) < 2 {
+		return 0, false
+	}
+	minor, err := strconv.Atoi(m[1])
+	return minor, err == nil
+}
+
+// NodeVersionBucketUnknown is the "NodeVersionBucket" label for a node
+// whose "KubeletVersion" could not be parsed, so it is never confused with
+// a legitimate "v1.0" bucket.
+const NodeVersionBucketUnknown = "unknown"
+
+// NodeVersionBucket returns the minor-version bucket label (e.g. "v1.24")
+// used to partition "RequestsWritesSummary"/"RequestsReadsSummary" outputs
+// across nodes running different kubelet versions, or
+// "NodeVersionBucketUnknown" if "info.KubeletVersion" could not be parsed.
+func NodeVersionBucket(info k8sobject.NodeInfo) string {
+	minor, ok := kubeletMinorVersion(info.KubeletVersion)
+	if !ok {
+		return NodeVersionBucketUnknown
+	}
+	return fmt.Sprintf("v1.%d", minor)
+}
+
 // EnvironmentVariablePrefixAddOnSecretsRemote is the environment variable prefix used for "eksconfig".
 const EnvironmentVariablePrefixAddOnSecretsRemote = AWS_K8S_TESTER_EKS_PREFIX + "ADD_ON_SECRETS_REMOTE_"
 
@@ -136,6 +356,10 @@ func getDefaultAddOnSecretsRemote() *AddOnSecretsRemote {
 		Objects:            10,
 		ObjectSize:         10 * 1024, // 10 KB
 
+		WritesPerWorker: 1,
+		ReadsPerWorker:  1,
+		LoadProfile:     LoadProfileConstant,
+
 		// writes total 100 MB for "Secret" objects,
 		// plus "Pod" objects, writes total 330 MB to etcd
 		//
@@ -147,6 +371,8 @@ func getDefaultAddOnSecretsRemote() *AddOnSecretsRemote {
 
 		NamePrefix: "secret" + randutil.String(5),
 
+		KMSProviders: []string{KMSProviderAESGCM},
+
 		RequestsWritesSummaryOutputNamePrefix: "secrets-writes-" + randutil.String(10),
 		RequestsReadsSummaryOutputNamePrefix:  "secrets-reads-" + randutil.String(10),
 	}
@@ -156,10 +382,6 @@ func (cfg *Config) validateAddOnSecretsRemote() error {
 	if !cfg.IsEnabledAddOnSecretsRemote() {
 		return nil
 	}
-	if cfg.S3BucketName == "" {
-		return errors.New("AddOnSecretsRemote requires S3 bucket for collecting results but S3BucketName empty")
-	}
-
 	if !cfg.IsEnabledAddOnNodeGroups() && !cfg.IsEnabledAddOnManagedNodeGroups() {
 		return errors.New("AddOnSecretsRemote.Enable true but no node group is enabled")
 	}
@@ -188,20 +410,84 @@ func (cfg *Config) validateAddOnSecretsRemote() error {
 		cfg.AddOnSecretsRemote.ObjectSize = 10 * 1024
 	}
 
+	if cfg.AddOnSecretsRemote.WritesPerWorker == 0 {
+		cfg.AddOnSecretsRemote.WritesPerWorker = 1
+	}
+	if cfg.AddOnSecretsRemote.ReadsPerWorker == 0 {
+		cfg.AddOnSecretsRemote.ReadsPerWorker = 1
+	}
+	if cfg.AddOnSecretsRemote.LoadProfile == "" {
+		cfg.AddOnSecretsRemote.LoadProfile = LoadProfileConstant
+	}
+	if _, ok := loadProfiles[cfg.AddOnSecretsRemote.LoadProfile]; !ok {
+		return fmt.Errorf("AddOnSecretsRemote.LoadProfile has unknown value %q", cfg.AddOnSecretsRemote.LoadProfile)
+	}
+	// effective writes/reads QPS is "DeploymentReplicas" * the respective
+	// per-worker concurrency, scaled down together when their sum exceeds
+	// the configured "TargetQPS". "TargetQPS" itself is left untouched so
+	// it remains the requested rate to compare "RequestsWrites/ReadsAchievedQPS" against.
+	writesQPS := float64(cfg.AddOnSecretsRemote.DeploymentReplicas) * float64(cfg.AddOnSecretsRemote.WritesPerWorker)
+	readsQPS := float64(cfg.AddOnSecretsRemote.DeploymentReplicas) * float64(cfg.AddOnSecretsRemote.ReadsPerWorker)
+	if total := writesQPS + readsQPS; cfg.AddOnSecretsRemote.TargetQPS > 0 && total > cfg.AddOnSecretsRemote.TargetQPS {
+		scale := cfg.AddOnSecretsRemote.TargetQPS / total
+		writesQPS *= scale
+		readsQPS *= scale
+	}
+	cfg.AddOnSecretsRemote.RequestsWritesEffectiveQPS = writesQPS
+	cfg.AddOnSecretsRemote.RequestsReadsEffectiveQPS = readsQPS
+
 	if cfg.AddOnSecretsRemote.NamePrefix == "" {
 		cfg.AddOnSecretsRemote.NamePrefix = "secret" + randutil.String(5)
 	}
 
-	if cfg.AddOnSecretsRemote.S3Dir == "" {
-		cfg.AddOnSecretsRemote.S3Dir = path.Join(cfg.Name, "add-on-secrets-remote")
+	if len(cfg.AddOnSecretsRemote.KMSProviders) == 0 {
+		cfg.AddOnSecretsRemote.KMSProviders = []string{KMSProviderAESGCM}
+	}
+	needsKMSPlugin := false
+	for _, provider := range cfg.AddOnSecretsRemote.KMSProviders {
+		if _, ok := kmsProviders[provider]; !ok {
+			return fmt.Errorf("AddOnSecretsRemote.KMSProviders has unknown provider %q", provider)
+		}
+		if provider == KMSProviderKMSV1 || provider == KMSProviderKMSV2 {
+			needsKMSPlugin = true
+		}
+	}
+	if needsKMSPlugin {
+		if cfg.AddOnSecretsRemote.KMSKeyARN == "" {
+			return errors.New("AddOnSecretsRemote.KMSProviders requires kms-v1/kms-v2 but KMSKeyARN empty")
+		}
+		if cfg.AddOnSecretsRemote.KMSPluginImage == "" {
+			return errors.New("AddOnSecretsRemote.KMSProviders requires kms-v1/kms-v2 but KMSPluginImage empty")
+		}
+	}
+
+	if cfg.AddOnSecretsRemote.NodeSelectorMinKubeletVersion < 0 {
+		return errors.New("AddOnSecretsRemote.NodeSelectorMinKubeletVersion must be >= 0")
+	}
+	if cfg.AddOnSecretsRemote.NodeSelectorKernelRegex != "" {
+		if _, err := regexp.Compile(cfg.AddOnSecretsRemote.NodeSelectorKernelRegex); err != nil {
+			return fmt.Errorf("AddOnSecretsRemote.NodeSelectorKernelRegex invalid (%v)", err)
+		}
+	}
+
+	if cfg.AddOnSecretsRemote.ResultsSinkDir.Path == "" {
+		if cfg.S3BucketName == "" {
+			return errors.New("AddOnSecretsRemote requires S3BucketName or ResultsSinkDir.Path for collecting results")
+		}
+		cfg.AddOnSecretsRemote.ResultsSinkDir = ResultsSink{
+			Path: fmt.Sprintf("s3://%s/%s", cfg.S3BucketName, path.Join(cfg.Name, "add-on-secrets-remote")),
+		}
+	}
+	if err := validateResultsSink(cfg.AddOnSecretsRemote.ResultsSinkDir); err != nil {
+		return fmt.Errorf("AddOnSecretsRemote.ResultsSinkDir invalid (%v)", err)
 	}
 
 	if cfg.AddOnSecretsRemote.RequestsWritesRawJSONPath == "" {
 		cfg.AddOnSecretsRemote.RequestsWritesRawJSONPath = strings.ReplaceAll(cfg.ConfigPath, ".yaml", "") + "-secrets-remote-requests-writes-raw.json"
 	}
-	if cfg.AddOnSecretsRemote.RequestsWritesRawJSONS3Key == "" {
-		cfg.AddOnSecretsRemote.RequestsWritesRawJSONS3Key = path.Join(
-			cfg.AddOnSecretsRemote.S3Dir,
+	if cfg.AddOnSecretsRemote.RequestsWritesRawJSONSink.Path == "" {
+		cfg.AddOnSecretsRemote.RequestsWritesRawJSONSink = joinSinkPath(
+			cfg.AddOnSecretsRemote.ResultsSinkDir,
 			"writes-raw",
 			filepath.Base(cfg.AddOnSecretsRemote.RequestsWritesRawJSONPath),
 		)
@@ -209,9 +495,9 @@ func (cfg *Config) validateAddOnSecretsRemote() error {
 	if cfg.AddOnSecretsRemote.RequestsWritesSummaryJSONPath == "" {
 		cfg.AddOnSecretsRemote.RequestsWritesSummaryJSONPath = strings.ReplaceAll(cfg.ConfigPath, ".yaml", "") + "-secrets-remote-requests-writes-summary.json"
 	}
-	if cfg.AddOnSecretsRemote.RequestsWritesSummaryJSONS3Key == "" {
-		cfg.AddOnSecretsRemote.RequestsWritesSummaryJSONS3Key = path.Join(
-			cfg.AddOnSecretsRemote.S3Dir,
+	if cfg.AddOnSecretsRemote.RequestsWritesSummaryJSONSink.Path == "" {
+		cfg.AddOnSecretsRemote.RequestsWritesSummaryJSONSink = joinSinkPath(
+			cfg.AddOnSecretsRemote.ResultsSinkDir,
 			"writes-summary",
 			filepath.Base(cfg.AddOnSecretsRemote.RequestsWritesSummaryJSONPath),
 		)
@@ -219,22 +505,30 @@ func (cfg *Config) validateAddOnSecretsRemote() error {
 	if cfg.AddOnSecretsRemote.RequestsWritesSummaryTablePath == "" {
 		cfg.AddOnSecretsRemote.RequestsWritesSummaryTablePath = strings.ReplaceAll(cfg.ConfigPath, ".yaml", "") + "-secrets-remote-requests-writes-summary.txt"
 	}
-	if cfg.AddOnSecretsRemote.RequestsWritesSummaryTableS3Key == "" {
-		cfg.AddOnSecretsRemote.RequestsWritesSummaryTableS3Key = path.Join(
-			cfg.AddOnSecretsRemote.S3Dir,
+	if cfg.AddOnSecretsRemote.RequestsWritesSummaryTableSink.Path == "" {
+		cfg.AddOnSecretsRemote.RequestsWritesSummaryTableSink = joinSinkPath(
+			cfg.AddOnSecretsRemote.ResultsSinkDir,
 			"writes-summary",
 			filepath.Base(cfg.AddOnSecretsRemote.RequestsWritesSummaryTablePath),
 		)
 	}
-	if cfg.AddOnSecretsRemote.RequestsWritesSummaryS3Dir == "" {
-		cfg.AddOnSecretsRemote.RequestsWritesSummaryS3Dir = path.Join("add-on-secrets-remote", "writes-summary", cfg.Parameters.Version)
+	if cfg.AddOnSecretsRemote.RequestsWritesSummarySinkDir.Path == "" {
+		if cfg.AddOnSecretsRemote.ResultsSinkDir.Host() == "" {
+			return fmt.Errorf("AddOnSecretsRemote.RequestsWritesSummarySinkDir must be set explicitly; ResultsSinkDir %q has no bucket/host to derive a cross-cluster regression directory from", cfg.AddOnSecretsRemote.ResultsSinkDir.Path)
+		}
+		cfg.AddOnSecretsRemote.RequestsWritesSummarySinkDir = joinSinkPath(
+			cfg.AddOnSecretsRemote.ResultsSinkDir.WithHost(),
+			"add-on-secrets-remote",
+			"writes-summary",
+			cfg.Parameters.Version,
+		)
 	}
 	if cfg.AddOnSecretsRemote.RequestsWritesSummaryCompareJSONPath == "" {
 		cfg.AddOnSecretsRemote.RequestsWritesSummaryCompareJSONPath = strings.ReplaceAll(cfg.ConfigPath, ".yaml", "") + "-secrets-remote-requests-writes-summary-compare.json"
 	}
-	if cfg.AddOnSecretsRemote.RequestsWritesSummaryCompareJSONS3Key == "" {
-		cfg.AddOnSecretsRemote.RequestsWritesSummaryCompareJSONS3Key = path.Join(
-			cfg.AddOnSecretsRemote.S3Dir,
+	if cfg.AddOnSecretsRemote.RequestsWritesSummaryCompareJSONSink.Path == "" {
+		cfg.AddOnSecretsRemote.RequestsWritesSummaryCompareJSONSink = joinSinkPath(
+			cfg.AddOnSecretsRemote.ResultsSinkDir,
 			"writes-compare",
 			filepath.Base(cfg.AddOnSecretsRemote.RequestsWritesSummaryCompareJSONPath),
 		)
@@ -242,9 +536,9 @@ func (cfg *Config) validateAddOnSecretsRemote() error {
 	if cfg.AddOnSecretsRemote.RequestsWritesSummaryCompareTablePath == "" {
 		cfg.AddOnSecretsRemote.RequestsWritesSummaryCompareTablePath = strings.ReplaceAll(cfg.ConfigPath, ".yaml", "") + "-secrets-remote-requests-writes-summary-compare.txt"
 	}
-	if cfg.AddOnSecretsRemote.RequestsWritesSummaryCompareTableS3Key == "" {
-		cfg.AddOnSecretsRemote.RequestsWritesSummaryCompareTableS3Key = path.Join(
-			cfg.AddOnSecretsRemote.S3Dir,
+	if cfg.AddOnSecretsRemote.RequestsWritesSummaryCompareTableSink.Path == "" {
+		cfg.AddOnSecretsRemote.RequestsWritesSummaryCompareTableSink = joinSinkPath(
+			cfg.AddOnSecretsRemote.ResultsSinkDir,
 			"writes-compare",
 			filepath.Base(cfg.AddOnSecretsRemote.RequestsWritesSummaryCompareTablePath),
 		)
@@ -253,9 +547,9 @@ func (cfg *Config) validateAddOnSecretsRemote() error {
 	if cfg.AddOnSecretsRemote.RequestsReadsRawJSONPath == "" {
 		cfg.AddOnSecretsRemote.RequestsReadsRawJSONPath = strings.ReplaceAll(cfg.ConfigPath, ".yaml", "") + "-secrets-remote-requests-reads-raw.json"
 	}
-	if cfg.AddOnSecretsRemote.RequestsReadsRawJSONS3Key == "" {
-		cfg.AddOnSecretsRemote.RequestsReadsRawJSONS3Key = path.Join(
-			cfg.AddOnSecretsRemote.S3Dir,
+	if cfg.AddOnSecretsRemote.RequestsReadsRawJSONSink.Path == "" {
+		cfg.AddOnSecretsRemote.RequestsReadsRawJSONSink = joinSinkPath(
+			cfg.AddOnSecretsRemote.ResultsSinkDir,
 			"reads-raw",
 			filepath.Base(cfg.AddOnSecretsRemote.RequestsReadsRawJSONPath),
 		)
@@ -263,9 +557,9 @@ func (cfg *Config) validateAddOnSecretsRemote() error {
 	if cfg.AddOnSecretsRemote.RequestsReadsSummaryJSONPath == "" {
 		cfg.AddOnSecretsRemote.RequestsReadsSummaryJSONPath = strings.ReplaceAll(cfg.ConfigPath, ".yaml", "") + "-secrets-remote-requests-reads-summary.json"
 	}
-	if cfg.AddOnSecretsRemote.RequestsReadsSummaryJSONS3Key == "" {
-		cfg.AddOnSecretsRemote.RequestsReadsSummaryJSONS3Key = path.Join(
-			cfg.AddOnSecretsRemote.S3Dir,
+	if cfg.AddOnSecretsRemote.RequestsReadsSummaryJSONSink.Path == "" {
+		cfg.AddOnSecretsRemote.RequestsReadsSummaryJSONSink = joinSinkPath(
+			cfg.AddOnSecretsRemote.ResultsSinkDir,
 			"reads-summary",
 			filepath.Base(cfg.AddOnSecretsRemote.RequestsReadsSummaryJSONPath),
 		)
@@ -273,22 +567,30 @@ func (cfg *Config) validateAddOnSecretsRemote() error {
 	if cfg.AddOnSecretsRemote.RequestsReadsSummaryTablePath == "" {
 		cfg.AddOnSecretsRemote.RequestsReadsSummaryTablePath = strings.ReplaceAll(cfg.ConfigPath, ".yaml", "") + "-secrets-remote-requests-reads-summary.txt"
 	}
-	if cfg.AddOnSecretsRemote.RequestsReadsSummaryTableS3Key == "" {
-		cfg.AddOnSecretsRemote.RequestsReadsSummaryTableS3Key = path.Join(
-			cfg.AddOnSecretsRemote.S3Dir,
+	if cfg.AddOnSecretsRemote.RequestsReadsSummaryTableSink.Path == "" {
+		cfg.AddOnSecretsRemote.RequestsReadsSummaryTableSink = joinSinkPath(
+			cfg.AddOnSecretsRemote.ResultsSinkDir,
 			"reads-summary",
 			filepath.Base(cfg.AddOnSecretsRemote.RequestsReadsSummaryTablePath),
 		)
 	}
-	if cfg.AddOnSecretsRemote.RequestsReadsSummaryS3Dir == "" {
-		cfg.AddOnSecretsRemote.RequestsReadsSummaryS3Dir = path.Join("add-on-secrets-remote", "reads-summary", cfg.Parameters.Version)
+	if cfg.AddOnSecretsRemote.RequestsReadsSummarySinkDir.Path == "" {
+		if cfg.AddOnSecretsRemote.ResultsSinkDir.Host() == "" {
+			return fmt.Errorf("AddOnSecretsRemote.RequestsReadsSummarySinkDir must be set explicitly; ResultsSinkDir %q has no bucket/host to derive a cross-cluster regression directory from", cfg.AddOnSecretsRemote.ResultsSinkDir.Path)
+		}
+		cfg.AddOnSecretsRemote.RequestsReadsSummarySinkDir = joinSinkPath(
+			cfg.AddOnSecretsRemote.ResultsSinkDir.WithHost(),
+			"add-on-secrets-remote",
+			"reads-summary",
+			cfg.Parameters.Version,
+		)
 	}
 	if cfg.AddOnSecretsRemote.RequestsReadsSummaryCompareJSONPath == "" {
 		cfg.AddOnSecretsRemote.RequestsReadsSummaryCompareJSONPath = strings.ReplaceAll(cfg.ConfigPath, ".yaml", "") + "-secrets-remote-requests-reads-summary-compare.json"
 	}
-	if cfg.AddOnSecretsRemote.RequestsReadsSummaryCompareJSONS3Key == "" {
-		cfg.AddOnSecretsRemote.RequestsReadsSummaryCompareJSONS3Key = path.Join(
-			cfg.AddOnSecretsRemote.S3Dir,
+	if cfg.AddOnSecretsRemote.RequestsReadsSummaryCompareJSONSink.Path == "" {
+		cfg.AddOnSecretsRemote.RequestsReadsSummaryCompareJSONSink = joinSinkPath(
+			cfg.AddOnSecretsRemote.ResultsSinkDir,
 			"reads-compare",
 			filepath.Base(cfg.AddOnSecretsRemote.RequestsReadsSummaryCompareJSONPath),
 		)
@@ -296,14 +598,18 @@ func (cfg *Config) validateAddOnSecretsRemote() error {
 	if cfg.AddOnSecretsRemote.RequestsReadsSummaryCompareTablePath == "" {
 		cfg.AddOnSecretsRemote.RequestsReadsSummaryCompareTablePath = strings.ReplaceAll(cfg.ConfigPath, ".yaml", "") + "-secrets-remote-requests-reads-summary-compare.txt"
 	}
-	if cfg.AddOnSecretsRemote.RequestsReadsSummaryCompareTableS3Key == "" {
-		cfg.AddOnSecretsRemote.RequestsReadsSummaryCompareTableS3Key = path.Join(
-			cfg.AddOnSecretsRemote.S3Dir,
+	if cfg.AddOnSecretsRemote.RequestsReadsSummaryCompareTableSink.Path == "" {
+		cfg.AddOnSecretsRemote.RequestsReadsSummaryCompareTableSink = joinSinkPath(
+			cfg.AddOnSecretsRemote.ResultsSinkDir,
 			"reads-compare",
 			filepath.Base(cfg.AddOnSecretsRemote.RequestsReadsSummaryCompareTablePath),
 		)
 	}
 
+	if cfg.AddOnSecretsRemote.RegressionThresholds == (RegressionThresholds{}) {
+		cfg.AddOnSecretsRemote.RegressionThresholds = defaultRegressionThresholds
+	}
+
 	if cfg.AddOnSecretsRemote.RequestsWritesSummaryOutputNamePrefix == "" {
 		cfg.AddOnSecretsRemote.RequestsWritesSummaryOutputNamePrefix = "secrets-writes-" + randutil.String(10)
 	}
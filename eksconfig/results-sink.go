@@ -0,0 +1,107 @@
+package eksconfig
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ResultsSink describes where a results artifact is uploaded to.
+// "Path" is a URL-style location, e.g. "s3://bucket/prefix/foo.json",
+// "gs://bucket/prefix/foo.json", or "file:///var/log/foo.json".
+// The scheme of "Path" selects the uploader used by the remote worker:
+// "s3" uploads via the worker Pod's AWS credentials (node IAM role),
+// "gs" and non-S3 "s3"-compatible endpoints (e.g. MinIO) read credentials
+// from "CredentialsSecretName", a Kubernetes Secret mounted into the worker Pod.
+type ResultsSink struct {
+	// Path is the URL-style destination for the artifact.
+	Path string `json:"path"`
+	// Endpoint overrides the default regional service endpoint.
+	// Required for MinIO and other S3-compatible backends.
+	Endpoint string `json:"endpoint,omitempty"`
+	// CredentialsSecretName is the name of the Kubernetes Secret, mounted
+	// into the worker Pod, holding the uploader's credentials
+	// (access key/secret for MinIO, service-account JSON for GCS).
+	// Not required for "s3" sinks on EKS, which use the node IAM role.
+	CredentialsSecretName string `json:"credentials-secret-name,omitempty"`
+}
+
+// resultsSinkSchemes is the set of "ResultsSink.Path" URL schemes the remote
+// worker knows how to upload to.
+var resultsSinkSchemes = map[string]struct{}{
+	"s3":   {},
+	"gs":   {},
+	"file": {},
+}
+
+// Scheme returns the URL scheme of "Path" (e.g. "s3", "gs", "file").
+// Returns an empty string if "Path" has no "scheme://" prefix.
+func (sink ResultsSink) Scheme() string {
+	idx := strings.Index(sink.Path, "://")
+	if idx < 0 {
+		return ""
+	}
+	return sink.Path[:idx]
+}
+
+// Host returns the "scheme://bucket" (or "scheme://host") prefix of "Path",
+// i.e. "Path" with the key/prefix segment that follows the bucket/host
+// stripped off. Used to derive sibling sinks that share the same bucket
+// but live under a different, cluster-independent prefix.
+// A "file://" sink has no bucket/authority to preserve (its path is
+// local to the worker Pod), so "Host" returns "" for it; callers must
+// check for an empty result rather than silently truncating to the root.
+func (sink ResultsSink) Host() string {
+	scheme := sink.Scheme()
+	if scheme == "" || scheme == "file" {
+		return ""
+	}
+	rest := strings.TrimPrefix(sink.Path, scheme+"://")
+	if idx := strings.Index(rest, "/"); idx >= 0 {
+		rest = rest[:idx]
+	}
+	return scheme + "://" + rest
+}
+
+// WithHost returns a copy of "sink" with "Path" replaced by "sink.Host()",
+// preserving "Endpoint" and "CredentialsSecretName" so a sink derived from
+// it (e.g. a cross-cluster regression directory) still carries the
+// credentials needed to reach a non-S3 backend.
+func (sink ResultsSink) WithHost() ResultsSink {
+	out := sink
+	out.Path = sink.Host()
+	return out
+}
+
+// validateResultsSink checks that "sink" has a supported scheme and,
+// for non-S3 backends, the credentials needed to reach it.
+func validateResultsSink(sink ResultsSink) error {
+	if sink.Path == "" {
+		return fmt.Errorf("ResultsSink.Path empty")
+	}
+	scheme := sink.Scheme()
+	if _, ok := resultsSinkSchemes[scheme]; !ok {
+		return fmt.Errorf("ResultsSink.Path %q has unsupported scheme %q", sink.Path, scheme)
+	}
+	nonS3 := scheme == "gs" || (scheme == "s3" && sink.Endpoint != "")
+	if nonS3 && sink.CredentialsSecretName == "" {
+		return fmt.Errorf("ResultsSink.Path %q requires CredentialsSecretName", sink.Path)
+	}
+	return nil
+}
+
+// joinSinkPath returns a copy of "base" with "elem" joined onto its "Path",
+// preserving the "scheme://" prefix (plain "path.Join" collapses the
+// double slash after the scheme).
+func joinSinkPath(base ResultsSink, elem ...string) ResultsSink {
+	sink := base
+	scheme := sink.Scheme()
+	rest := sink.Path
+	prefix := ""
+	if scheme != "" {
+		prefix = scheme + "://"
+		rest = strings.TrimPrefix(sink.Path, prefix)
+	}
+	sink.Path = prefix + path.Join(append([]string{rest}, elem...)...)
+	return sink
+}